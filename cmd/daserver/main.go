@@ -0,0 +1,184 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+// daserver fronts a DataAvailabilityService (typically local-disk) with
+// the HTTP+JSON and gRPC APIs that das.RemoteDataAvailabilityService
+// speaks, so it can be run as a standalone remote DAS endpoint.
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/offchainlabs/nitro/arbstate"
+	"github.com/offchainlabs/nitro/das"
+	"github.com/offchainlabs/nitro/das/dasrpc"
+)
+
+type config struct {
+	HTTPAddr string
+	GRPCAddr string
+	DataDir  string
+	KeyDir   string
+	PrivKey  string
+}
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.HTTPAddr, "http-addr", "localhost:9876", "address to listen for HTTP+JSON requests on")
+	flag.StringVar(&cfg.GRPCAddr, "grpc-addr", "localhost:9877", "address to listen for gRPC requests on")
+	flag.StringVar(&cfg.DataDir, "data-dir", "", "directory to store data availability certificates in")
+	flag.StringVar(&cfg.KeyDir, "key-dir", "", "directory containing the BLS keypair used to sign certificates")
+	flag.StringVar(&cfg.PrivKey, "priv-key", "", "raw BLS private key used to sign certificates, instead of --key-dir")
+	flag.Parse()
+
+	backend, err := das.NewLocalDiskDataAvailabilityService(das.LocalDiskDASConfig{
+		DataDir: cfg.DataDir,
+		KeyDir:  cfg.KeyDir,
+		PrivKey: cfg.PrivKey,
+	})
+	if err != nil {
+		log.Fatalf("error creating local disk DAS: %v", err)
+	}
+
+	go serveGRPC(cfg.GRPCAddr, backend)
+	serveHTTP(cfg.HTTPAddr, backend)
+}
+
+func serveHTTP(addr string, backend das.DataAvailabilityService) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/store", httpStoreHandler(backend))
+	mux.HandleFunc("/get-by-hash/", httpGetByHashHandler(backend))
+
+	log.Printf("daserver listening for HTTP+JSON on %s", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Fatalf("HTTP server error: %v", err)
+	}
+}
+
+func httpStoreHandler(backend das.DataAvailabilityService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var req struct {
+			Message []byte `json:"message"`
+			Timeout uint64 `json:"timeout"`
+			Sig     []byte `json:"sig"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		cert, err := backend.Store(r.Context(), req.Message, req.Timeout, req.Sig)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if das.IsTerminalError(err) {
+				status = http.StatusBadRequest
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		resp, err := json.Marshal(struct {
+			DataAvailabilityCertificate []byte `json:"dataAvailabilityCertificate"`
+		}{das.Serialize(cert)})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(resp)
+	}
+}
+
+func httpGetByHashHandler(backend das.DataAvailabilityService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		encodedHash := r.URL.Path[len("/get-by-hash/"):]
+		hashBytes, err := base64.URLEncoding.DecodeString(encodedHash)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		data, err := backend.GetByHash(r.Context(), common.BytesToHash(hashBytes))
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, arbstate.ErrNotFound) {
+				status = http.StatusNotFound
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		resp, err := json.Marshal(struct {
+			Data []byte `json:"data"`
+		}{data})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(resp)
+	}
+}
+
+type grpcServer struct {
+	dasrpc.UnimplementedDASServer
+	backend das.DataAvailabilityService
+}
+
+func (s *grpcServer) Store(ctx context.Context, req *dasrpc.StoreRequest) (*dasrpc.StoreResponse, error) {
+	cert, err := s.backend.Store(ctx, req.Message, req.Timeout, req.Sig)
+	if err != nil {
+		return nil, err
+	}
+	return &dasrpc.StoreResponse{DataAvailabilityCertificate: das.Serialize(cert)}, nil
+}
+
+func (s *grpcServer) GetByHash(ctx context.Context, req *dasrpc.GetByHashRequest) (*dasrpc.GetByHashResponse, error) {
+	data, err := s.backend.GetByHash(ctx, common.BytesToHash(req.DataHash))
+	if err != nil {
+		if errors.Is(err, arbstate.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, err
+	}
+	return &dasrpc.GetByHashResponse{Data: data}, nil
+}
+
+func serveGRPC(addr string, backend das.DataAvailabilityService) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalf("error listening on %s: %v", addr, err)
+	}
+
+	s := grpc.NewServer()
+	dasrpc.RegisterDASServer(s, &grpcServer{backend: backend})
+
+	log.Printf("daserver listening for gRPC on %s", addr)
+	if err := s.Serve(lis); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -1,10 +1,13 @@
 package util
 
 import (
+	"encoding/json"
+	"strconv"
 	"strings"
 
 	"github.com/knadh/koanf"
-	"github.com/knadh/koanf/parsers/json"
+	jsonparser "github.com/knadh/koanf/parsers/json"
+	"github.com/knadh/koanf/providers/confmap"
 	"github.com/knadh/koanf/providers/env"
 	"github.com/knadh/koanf/providers/file"
 	"github.com/knadh/koanf/providers/posflag"
@@ -35,7 +38,7 @@ func applyOverrides(f *flag.FlagSet, k *koanf.Koanf) error {
 	// Local config file overrides S3 config file
 	configFile := k.String("conf.file")
 	if len(configFile) > 0 {
-		if err := k.Load(file.Provider(configFile), json.Parser()); err != nil {
+		if err := k.Load(file.Provider(configFile), jsonparser.Parser(), koanf.WithMergeFunc(mergeFuncFor(k))); err != nil {
 			return errors.Wrap(err, "error loading local config file")
 		}
 
@@ -44,25 +47,32 @@ func applyOverrides(f *flag.FlagSet, k *koanf.Koanf) error {
 		}
 	}
 
+	// Patch operations apply last, after every provider has merged in, so they
+	// can target a single deeply-nested field without having to restate the
+	// structures around it.
+	if err := applyConfPatch(k); err != nil {
+		return errors.Wrap(err, "error applying conf.patch")
+	}
+
 	return nil
 }
 
 // applyOverrideOverrides for configuration values that need to be re-applied for each configuration item applied
 func applyOverrideOverrides(f *flag.FlagSet, k *koanf.Koanf) error {
 	// Command line overrides config file or config string
-	if err := k.Load(posflag.Provider(f, ".", k), nil); err != nil {
+	if err := k.Load(posflag.Provider(f, ".", k), nil, koanf.WithMergeFunc(mergeFuncFor(k))); err != nil {
 		return errors.Wrap(err, "error loading command line config")
 	}
 
 	// Config string overrides any config file
 	configString := k.String("conf.string")
 	if len(configString) > 0 {
-		if err := k.Load(rawbytes.Provider([]byte(configString)), json.Parser()); err != nil {
+		if err := k.Load(rawbytes.Provider([]byte(configString)), jsonparser.Parser(), koanf.WithMergeFunc(mergeFuncFor(k))); err != nil {
 			return errors.Wrap(err, "error loading config string config")
 		}
 
 		// Command line overrides config file or config string
-		if err := k.Load(posflag.Provider(f, ".", k), nil); err != nil {
+		if err := k.Load(posflag.Provider(f, ".", k), nil, koanf.WithMergeFunc(mergeFuncFor(k))); err != nil {
 			return errors.Wrap(err, "error loading command line config")
 		}
 	}
@@ -83,7 +93,7 @@ func loadEnvironmentVariables(k *koanf.Koanf) error {
 			s = strings.ReplaceAll(strings.ToLower(
 				strings.TrimPrefix(s, envPrefix+"_")), "__", "-")
 			return strings.ReplaceAll(s, "_", ".")
-		}), nil)
+		}), nil, koanf.WithMergeFunc(mergeFuncFor(k)))
 	}
 
 	return nil
@@ -96,7 +106,254 @@ func loadS3Variables(k *koanf.Koanf) error {
 		Region:    k.String("conf.s3.region"),
 		Bucket:    k.String("conf.s3.bucket"),
 		ObjectKey: k.String("conf.s3.object-key"),
-	}), nil)
+	}), nil, koanf.WithMergeFunc(mergeFuncFor(k)))
+}
+
+// SliceMergeStrategy controls what mergeMaps does when the same key holds
+// a slice in both src and dest.
+type SliceMergeStrategy string
+
+const (
+	// SliceMergeReplace replaces dest's slice with src's wholesale. This is
+	// the default: it matches how every other provider in this file has
+	// always behaved for non-map values.
+	SliceMergeReplace SliceMergeStrategy = "replace"
+	// SliceMergeAppend appends src's elements after dest's.
+	SliceMergeAppend SliceMergeStrategy = "append"
+	// SliceMergeByIndex merges src[i] into dest[i] for overlapping indices
+	// (recursing when both elements are maps), and appends any of src's
+	// elements beyond dest's length.
+	SliceMergeByIndex SliceMergeStrategy = "by-index"
+)
+
+// DefaultSliceMergeStrategy is used when conf.slice-merge-strategy is unset
+// or set to an unrecognized value.
+const DefaultSliceMergeStrategy = SliceMergeReplace
+
+// mergeFuncFor returns a koanf merge function bound to the slice-merge
+// strategy currently configured in k (conf.slice-merge-strategy), for use
+// with koanf.WithMergeFunc.
+func mergeFuncFor(k *koanf.Koanf) func(src, dest map[string]interface{}) error {
+	strategy := SliceMergeStrategy(k.String("conf.slice-merge-strategy"))
+	switch strategy {
+	case SliceMergeAppend, SliceMergeByIndex:
+	default:
+		strategy = DefaultSliceMergeStrategy
+	}
+
+	return func(src, dest map[string]interface{}) error {
+		return mergeMaps(src, dest, strategy)
+	}
+}
+
+// mergeMaps deep-merges src into dest, recursing into nested
+// map[string]interface{} values so that a provider loaded later only
+// overrides the specific fields it sets rather than clobbering its
+// siblings. Scalars in src always replace the corresponding value in
+// dest; slices are merged according to strategy.
+func mergeMaps(src, dest map[string]interface{}, strategy SliceMergeStrategy) error {
+	for key, srcVal := range src {
+		destVal, exists := dest[key]
+		if !exists {
+			dest[key] = srcVal
+			continue
+		}
+
+		if srcMap, ok := srcVal.(map[string]interface{}); ok {
+			if destMap, ok := destVal.(map[string]interface{}); ok {
+				if err := mergeMaps(srcMap, destMap, strategy); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if srcSlice, ok := srcVal.([]interface{}); ok {
+			if destSlice, ok := destVal.([]interface{}); ok {
+				merged, err := mergeSlices(srcSlice, destSlice, strategy)
+				if err != nil {
+					return err
+				}
+				dest[key] = merged
+				continue
+			}
+		}
+
+		dest[key] = srcVal
+	}
+
+	return nil
+}
+
+func mergeSlices(src, dest []interface{}, strategy SliceMergeStrategy) ([]interface{}, error) {
+	switch strategy {
+	case SliceMergeAppend:
+		return append(append([]interface{}{}, dest...), src...), nil
+
+	case SliceMergeByIndex:
+		merged := append([]interface{}{}, dest...)
+		for i, srcElem := range src {
+			if i >= len(merged) {
+				merged = append(merged, srcElem)
+				continue
+			}
+			if srcMap, ok := srcElem.(map[string]interface{}); ok {
+				if destMap, ok := merged[i].(map[string]interface{}); ok {
+					if err := mergeMaps(srcMap, destMap, strategy); err != nil {
+						return nil, err
+					}
+					continue
+				}
+			}
+			merged[i] = srcElem
+		}
+		return merged, nil
+
+	default: // SliceMergeReplace
+		return src, nil
+	}
+}
+
+// patchOp is a single JSON-pointer style override, as loaded from
+// conf.patch: {"op": "replace", "path": "/a/b/0/c", "value": "..."}.
+// Supported ops are "replace"/"add" (equivalent here, both set the value
+// at path, creating it if missing from a map) and "remove".
+type patchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// applyConfPatch applies conf.patch, a JSON array of JSON-pointer style
+// patch operations, to the fully-merged configuration tree. It runs after
+// every other provider has loaded so a patch can override one field deep
+// inside a structure (e.g. a single aggregator backend URL) without
+// having to restate the structure around it.
+func applyConfPatch(k *koanf.Koanf) error {
+	patchString := k.String("conf.patch")
+	if len(patchString) == 0 {
+		return nil
+	}
+
+	var ops []patchOp
+	if err := json.Unmarshal([]byte(patchString), &ops); err != nil {
+		return errors.Wrap(err, "error parsing conf.patch")
+	}
+
+	doc := k.Raw()
+	for _, op := range ops {
+		if err := applyPatchOp(doc, op); err != nil {
+			return errors.Wrapf(err, "error applying conf.patch operation %+v", op)
+		}
+	}
+
+	patched := koanf.New(".")
+	if err := patched.Load(confmap.Provider(doc, "."), nil); err != nil {
+		return err
+	}
+	*k = *patched
+
+	return nil
+}
+
+func applyPatchOp(doc map[string]interface{}, op patchOp) error {
+	segments, err := splitJSONPointer(op.Path)
+	if err != nil {
+		return err
+	}
+	if len(segments) == 0 {
+		return errors.New("conf.patch path must not be empty")
+	}
+
+	switch op.Op {
+	case "replace", "add":
+		return setJSONPointer(doc, segments, op.Value)
+	case "remove":
+		return removeJSONPointer(doc, segments)
+	default:
+		return errors.Errorf("unsupported conf.patch op %q", op.Op)
+	}
+}
+
+// splitJSONPointer splits an RFC 6901 JSON pointer into its unescaped
+// reference tokens, e.g. "/a/b~1c/0" -> ["a", "b/c", "0"].
+func splitJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, errors.Errorf("conf.patch path %q must start with '/'", pointer)
+	}
+
+	rawSegments := strings.Split(pointer[1:], "/")
+	segments := make([]string, len(rawSegments))
+	for i, s := range rawSegments {
+		s = strings.ReplaceAll(s, "~1", "/")
+		s = strings.ReplaceAll(s, "~0", "~")
+		segments[i] = s
+	}
+	return segments, nil
+}
+
+// resolveJSONPointerParent walks doc to the container holding the final
+// path segment, returning that container and the final segment.
+func resolveJSONPointerParent(doc map[string]interface{}, segments []string) (interface{}, string, error) {
+	var cur interface{} = doc
+	for _, seg := range segments[:len(segments)-1] {
+		switch c := cur.(type) {
+		case map[string]interface{}:
+			next, ok := c[seg]
+			if !ok {
+				return nil, "", errors.Errorf("path segment %q not found", seg)
+			}
+			cur = next
+		case []interface{}:
+			idx, err := strconv.Atoi(seg)
+			if err != nil || idx < 0 || idx >= len(c) {
+				return nil, "", errors.Errorf("invalid array index %q", seg)
+			}
+			cur = c[idx]
+		default:
+			return nil, "", errors.Errorf("cannot traverse into %T at %q", cur, seg)
+		}
+	}
+	return cur, segments[len(segments)-1], nil
+}
+
+func setJSONPointer(doc map[string]interface{}, segments []string, value interface{}) error {
+	parent, last, err := resolveJSONPointerParent(doc, segments)
+	if err != nil {
+		return err
+	}
+
+	switch c := parent.(type) {
+	case map[string]interface{}:
+		c[last] = value
+		return nil
+	case []interface{}:
+		idx, err := strconv.Atoi(last)
+		if err != nil || idx < 0 || idx >= len(c) {
+			return errors.Errorf("invalid array index %q", last)
+		}
+		c[idx] = value
+		return nil
+	default:
+		return errors.Errorf("cannot set value into %T", parent)
+	}
+}
+
+func removeJSONPointer(doc map[string]interface{}, segments []string) error {
+	parent, last, err := resolveJSONPointerParent(doc, segments)
+	if err != nil {
+		return err
+	}
+
+	c, ok := parent.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("conf.patch remove is only supported on map fields, got %T", parent)
+	}
+	delete(c, last)
+	return nil
 }
 
 func BeginCommonParse(f *flag.FlagSet, args []string) (*koanf.Koanf, error) {
@@ -136,4 +393,4 @@ func EndCommonParse(k *koanf.Koanf, config interface{}) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,211 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeMapsDeepMerge(t *testing.T) {
+	dest := map[string]interface{}{
+		"data-availability": map[string]interface{}{
+			"mode": "aggregator",
+			"aggregator": map[string]interface{}{
+				"assumed-honest": float64(1),
+				"backends":       "base-backends",
+			},
+		},
+	}
+	src := map[string]interface{}{
+		"data-availability": map[string]interface{}{
+			"aggregator": map[string]interface{}{
+				"assumed-honest": float64(2),
+			},
+		},
+	}
+
+	if err := mergeMaps(src, dest, SliceMergeReplace); err != nil {
+		t.Fatalf("mergeMaps returned error: %v", err)
+	}
+
+	da := dest["data-availability"].(map[string]interface{})
+	if da["mode"] != "aggregator" {
+		t.Errorf("expected sibling field 'mode' to survive the merge untouched, got %v", da["mode"])
+	}
+	agg := da["aggregator"].(map[string]interface{})
+	if agg["assumed-honest"] != float64(2) {
+		t.Errorf("expected assumed-honest to be overridden to 2, got %v", agg["assumed-honest"])
+	}
+	if agg["backends"] != "base-backends" {
+		t.Errorf("expected sibling field 'backends' to survive the merge untouched, got %v", agg["backends"])
+	}
+}
+
+func TestMergeMapsSliceStrategies(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy SliceMergeStrategy
+		dest     []interface{}
+		src      []interface{}
+		want     []interface{}
+	}{
+		{
+			name:     "replace",
+			strategy: SliceMergeReplace,
+			dest:     []interface{}{"a", "b"},
+			src:      []interface{}{"c"},
+			want:     []interface{}{"c"},
+		},
+		{
+			name:     "append",
+			strategy: SliceMergeAppend,
+			dest:     []interface{}{"a", "b"},
+			src:      []interface{}{"c"},
+			want:     []interface{}{"a", "b", "c"},
+		},
+		{
+			name:     "by-index overlapping scalar",
+			strategy: SliceMergeByIndex,
+			dest:     []interface{}{"a", "b"},
+			src:      []interface{}{"c"},
+			want:     []interface{}{"c", "b"},
+		},
+		{
+			name:     "by-index extends past dest length",
+			strategy: SliceMergeByIndex,
+			dest:     []interface{}{"a"},
+			src:      []interface{}{"b", "c"},
+			want:     []interface{}{"b", "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeSlices(tt.src, tt.dest, tt.strategy)
+			if err != nil {
+				t.Fatalf("mergeSlices returned error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeSlices() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeMapsSliceByIndexRecursesIntoMapElements(t *testing.T) {
+	dest := map[string]interface{}{
+		"backends": []interface{}{
+			map[string]interface{}{"url": "https://base", "timeout": float64(5)},
+		},
+	}
+	src := map[string]interface{}{
+		"backends": []interface{}{
+			map[string]interface{}{"url": "https://override"},
+		},
+	}
+
+	if err := mergeMaps(src, dest, SliceMergeByIndex); err != nil {
+		t.Fatalf("mergeMaps returned error: %v", err)
+	}
+
+	backends := dest["backends"].([]interface{})
+	backend := backends[0].(map[string]interface{})
+	if backend["url"] != "https://override" {
+		t.Errorf("expected url to be overridden, got %v", backend["url"])
+	}
+	if backend["timeout"] != float64(5) {
+		t.Errorf("expected sibling field 'timeout' to survive the by-index merge, got %v", backend["timeout"])
+	}
+}
+
+func TestApplyPatchOpReplaceNestedSliceElement(t *testing.T) {
+	doc := map[string]interface{}{
+		"data-availability": map[string]interface{}{
+			"aggregator": map[string]interface{}{
+				"backends": []interface{}{
+					map[string]interface{}{"url": "https://a"},
+					map[string]interface{}{"url": "https://b"},
+				},
+			},
+		},
+	}
+
+	err := applyPatchOp(doc, patchOp{
+		Op:    "replace",
+		Path:  "/data-availability/aggregator/backends/0/url",
+		Value: "https://patched",
+	})
+	if err != nil {
+		t.Fatalf("applyPatchOp returned error: %v", err)
+	}
+
+	da := doc["data-availability"].(map[string]interface{})
+	agg := da["aggregator"].(map[string]interface{})
+	backends := agg["backends"].([]interface{})
+	first := backends[0].(map[string]interface{})
+	if first["url"] != "https://patched" {
+		t.Errorf("expected first backend's url to be patched, got %v", first["url"])
+	}
+	second := backends[1].(map[string]interface{})
+	if second["url"] != "https://b" {
+		t.Errorf("expected second backend's url to be untouched, got %v", second["url"])
+	}
+}
+
+func TestApplyPatchOpAddNewMapField(t *testing.T) {
+	doc := map[string]interface{}{
+		"data-availability": map[string]interface{}{},
+	}
+
+	err := applyPatchOp(doc, patchOp{
+		Op:    "add",
+		Path:  "/data-availability/mode",
+		Value: "s3",
+	})
+	if err != nil {
+		t.Fatalf("applyPatchOp returned error: %v", err)
+	}
+
+	da := doc["data-availability"].(map[string]interface{})
+	if da["mode"] != "s3" {
+		t.Errorf("expected mode to be added, got %v", da["mode"])
+	}
+}
+
+func TestApplyPatchOpRemove(t *testing.T) {
+	doc := map[string]interface{}{
+		"data-availability": map[string]interface{}{
+			"store-signer": "0xabc",
+		},
+	}
+
+	if err := applyPatchOp(doc, patchOp{Op: "remove", Path: "/data-availability/store-signer"}); err != nil {
+		t.Fatalf("applyPatchOp returned error: %v", err)
+	}
+
+	da := doc["data-availability"].(map[string]interface{})
+	if _, exists := da["store-signer"]; exists {
+		t.Errorf("expected store-signer to be removed")
+	}
+}
+
+func TestApplyPatchOpInvalidIndex(t *testing.T) {
+	doc := map[string]interface{}{
+		"backends": []interface{}{"a"},
+	}
+
+	err := applyPatchOp(doc, patchOp{Op: "replace", Path: "/backends/5", Value: "b"})
+	if err == nil {
+		t.Fatalf("expected an error for an out-of-range array index, got nil")
+	}
+}
+
+func TestSplitJSONPointerUnescapesTokens(t *testing.T) {
+	segments, err := splitJSONPointer("/a~1b/c~0d")
+	if err != nil {
+		t.Fatalf("splitJSONPointer returned error: %v", err)
+	}
+	want := []string{"a/b", "c~d"}
+	if !reflect.DeepEqual(segments, want) {
+		t.Errorf("splitJSONPointer() = %v, want %v", segments, want)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"reflect"
 	"strings"
 
@@ -36,20 +37,28 @@ const (
 	OnchainDataAvailability DataAvailabilityMode = iota
 	LocalDiskDataAvailability
 	AggregatorDataAvailability
-	// TODO RemoteDataAvailability
+	RemoteDataAvailability
+	RedundantDataAvailability
+	S3DataAvailability
 )
 
 const (
 	OnchainDataAvailabilityString    = "onchain"
 	LocalDiskDataAvailabilityString  = "local-disk"
 	AggregatorDataAvailabilityString = "aggregator"
-	// TODO RemoteDataAvailability
+	RemoteDataAvailabilityString     = "remote"
+	RedundantDataAvailabilityString  = "redundant"
+	S3DataAvailabilityString         = "s3"
 )
 
 type DataAvailabilityConfig struct {
 	ModeImpl           string             `koanf:"mode"`
 	LocalDiskDASConfig LocalDiskDASConfig `koanf:"local-disk"`
 	AggregatorConfig   AggregatorConfig   `koanf:"aggregator"`
+	RemoteDASConfig    RemoteDASConfig    `koanf:"remote"`
+	RetryConfig        RetryConfig        `koanf:"retry"`
+	RedundantConfig    RedundantConfig    `koanf:"redundant"`
+	S3Config           S3DASConfig        `koanf:"s3"`
 	StoreSignerAddress string             `koanf:"store-signer"` // if empty string, no signer is required
 }
 
@@ -83,10 +92,71 @@ func (c *DataAvailabilityConfig) Mode() (DataAvailabilityMode, error) {
 		return AggregatorDataAvailability, nil
 	}
 
+	if c.ModeImpl == RemoteDataAvailabilityString {
+		if len(c.RemoteDASConfig.URLs) == 0 {
+			flag.Usage()
+			return 0, errors.New("--data-availability.remote.urls must be specified if mode is set to remote")
+		}
+		return RemoteDataAvailability, nil
+	}
+
+	if c.ModeImpl == RedundantDataAvailabilityString {
+		if len(c.RedundantConfig.Backends) == 0 {
+			flag.Usage()
+			return 0, errors.New("--data-availability.redundant.backends must be specified if mode is set to redundant")
+		}
+		return RedundantDataAvailability, nil
+	}
+
+	if c.ModeImpl == S3DataAvailabilityString {
+		if c.S3Config.Bucket == "" || (c.S3Config.KeyDir == "" && c.S3Config.PrivKey == "") {
+			flag.Usage()
+			return 0, errors.New("--data-availability.s3.bucket and .key-dir (or .priv-key) must be specified if mode is set to s3")
+		}
+		return S3DataAvailability, nil
+	}
+
 	flag.Usage()
 	return 0, errors.New("--data-availability.mode " + c.ModeImpl + " not recognized")
 }
 
+// NewDataAvailabilityService constructs the DataAvailabilityService selected
+// by config.Mode(). config.RetryConfig is applied to the single-backend
+// modes (local-disk, remote, s3); redundant mode manages retry per-backend
+// itself via RedundantBackendConfig.RetryConfig and is returned unwrapped.
+func NewDataAvailabilityService(config DataAvailabilityConfig) (DataAvailabilityService, error) {
+	mode, err := config.Mode()
+	if err != nil {
+		return nil, err
+	}
+
+	var backend DataAvailabilityService
+	switch mode {
+	case OnchainDataAvailability:
+		return nil, nil
+	case LocalDiskDataAvailability:
+		backend, err = NewLocalDiskDataAvailabilityService(config.LocalDiskDASConfig)
+	case RemoteDataAvailability:
+		backend, err = NewRemoteDataAvailabilityService(config.RemoteDASConfig)
+	case S3DataAvailability:
+		backend, err = NewS3DataAvailabilityService(config.S3Config)
+	case RedundantDataAvailability:
+		return NewRedundantDataAvailabilityService(config.RedundantConfig)
+	case AggregatorDataAvailability:
+		return nil, errors.New("--data-availability.mode aggregator is not implemented in this build")
+	default:
+		return nil, fmt.Errorf("--data-availability.mode %q has no constructor", config.ModeImpl)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if config.RetryConfig.Enable {
+		return NewRetryWrapper(backend, config.RetryConfig)
+	}
+	return backend, nil
+}
+
 func StoreSignerAddressFromString(s string) (*common.Address, error) {
 	if s == "none" {
 		return nil, nil
@@ -101,12 +171,25 @@ func StoreSignerAddressFromString(s string) (*common.Address, error) {
 }
 
 func DataAvailabilityConfigAddOptions(prefix string, f *flag.FlagSet) {
-	f.String(prefix+".mode", DefaultDataAvailabilityConfig.ModeImpl, "mode ('onchain', 'local-disk', or 'aggregator')")
+	f.String(prefix+".mode", DefaultDataAvailabilityConfig.ModeImpl, "mode ('onchain', 'local-disk', 'aggregator', 'remote', 'redundant', or 's3')")
 	LocalDiskDASConfigAddOptions(prefix+".local-disk", f)
 	AggregatorConfigAddOptions(prefix+".aggregator", f)
+	RemoteDASConfigAddOptions(prefix+".remote", f)
+	RetryConfigAddOptions(prefix+".retry", f)
+	RedundantConfigAddOptions(prefix+".redundant", f)
+	S3DASConfigAddOptions(prefix+".s3", f)
 	f.String(prefix+".store-signer", DefaultDataAvailabilityConfig.StoreSignerAddress, "hex-encoded address of required Store signer, or empty string if none")
 }
 
+// DASDataHash is the canonical hash used for a DataAvailabilityCertificate's
+// DataHash field. Every DataAvailabilityService implementation must hash
+// the stored message this way: a backend that hashes differently (even
+// correctly) would store objects under a key no other backend or
+// verifier could ever look up by DataHash.
+func DASDataHash(message []byte) common.Hash {
+	return crypto.Keccak256Hash(message)
+}
+
 func serializeSignableFields(c *arbstate.DataAvailabilityCertificate) []byte {
 	buf := make([]byte, 0, 32+8)
 	buf = append(buf, c.DataHash[:]...)
@@ -133,3 +216,44 @@ func Serialize(c *arbstate.DataAvailabilityCertificate) []byte {
 
 	return append(buf, blsSignatures.SignatureToBytes(c.Sig)...)
 }
+
+// Deserialize parses the wire format produced by Serialize back into a
+// DataAvailabilityCertificate. It is the inverse of Serialize and is used
+// by DataAvailabilityService implementations that receive certificates
+// over the network (e.g. the remote DAS client).
+func Deserialize(data []byte) (*arbstate.DataAvailabilityCertificate, error) {
+	if len(data) < 1+32+32+8+8 {
+		return nil, errors.New("data availability certificate too short")
+	}
+	if data[0] != arbstate.DASMessageHeaderFlag {
+		return nil, fmt.Errorf("unexpected data availability certificate header flag: %v", data[0])
+	}
+	data = data[1:]
+
+	var keysetHash [32]byte
+	copy(keysetHash[:], data[:32])
+	data = data[32:]
+
+	var dataHash [32]byte
+	copy(dataHash[:], data[:32])
+	data = data[32:]
+
+	timeout := binary.BigEndian.Uint64(data[:8])
+	data = data[8:]
+
+	signersMask := binary.BigEndian.Uint64(data[:8])
+	data = data[8:]
+
+	sig, err := blsSignatures.SignatureFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &arbstate.DataAvailabilityCertificate{
+		DataHash:    dataHash,
+		Timeout:     timeout,
+		SignersMask: signersMask,
+		Sig:         sig,
+		KeysetHash:  keysetHash,
+	}, nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+// Package dasrpc is the gRPC counterpart of the remote DataAvailabilityService
+// HTTP API described in das.proto.
+//
+// These types and the client/server plumbing below are hand-maintained to
+// match das.proto's schema, not protoc output: running them through the
+// real protoc/protoc-gen-go-grpc toolchain would normally produce
+// proto.Message-shaped structs that marshal to the protobuf wire format.
+// Absent that toolchain, requests and responses here are plain JSON-tagged
+// structs, and init() below registers a codec that marshals with
+// encoding/json instead, under its own content-subtype name so it only
+// applies to this package's calls (see jsonCodecName) rather than
+// replacing gRPC's global "proto" codec for the whole process. If protoc
+// tooling becomes available, this file should be replaced with real
+// generated code and the codec registration removed.
+package dasrpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// jsonCodecName is the gRPC content-subtype the DAS client requests via
+// grpc.CallContentSubtype on every call, so only dasrpc traffic is
+// affected by jsonCodec; every other gRPC client/server in the process
+// keeps using the default protobuf codec.
+const jsonCodecName = "dasrpc-json"
+
+// jsonCodec implements grpc/encoding.Codec, registered under jsonCodecName.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+func (jsonCodec) Name() string                               { return jsonCodecName }
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+type StoreRequest struct {
+	Message []byte `json:"message"`
+	Timeout uint64 `json:"timeout"`
+	Sig     []byte `json:"sig"`
+}
+
+type StoreResponse struct {
+	// The serialized form of an arbstate.DataAvailabilityCertificate, as
+	// produced by das.Serialize.
+	DataAvailabilityCertificate []byte `json:"dataAvailabilityCertificate"`
+}
+
+type GetByHashRequest struct {
+	DataHash []byte `json:"dataHash"`
+}
+
+type GetByHashResponse struct {
+	Data []byte `json:"data"`
+}
+
+type DASClient interface {
+	Store(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*StoreResponse, error)
+	GetByHash(ctx context.Context, in *GetByHashRequest, opts ...grpc.CallOption) (*GetByHashResponse, error)
+}
+
+type dASClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDASClient(cc grpc.ClientConnInterface) DASClient {
+	return &dASClient{cc}
+}
+
+func (c *dASClient) Store(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*StoreResponse, error) {
+	out := new(StoreResponse)
+	opts = append(opts, grpc.CallContentSubtype(jsonCodecName))
+	if err := c.cc.Invoke(ctx, "/dasrpc.DAS/Store", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dASClient) GetByHash(ctx context.Context, in *GetByHashRequest, opts ...grpc.CallOption) (*GetByHashResponse, error) {
+	out := new(GetByHashResponse)
+	opts = append(opts, grpc.CallContentSubtype(jsonCodecName))
+	if err := c.cc.Invoke(ctx, "/dasrpc.DAS/GetByHash", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DASServer is the server API for the DAS service.
+type DASServer interface {
+	Store(context.Context, *StoreRequest) (*StoreResponse, error)
+	GetByHash(context.Context, *GetByHashRequest) (*GetByHashResponse, error)
+}
+
+// UnimplementedDASServer may be embedded to have forward compatible implementations.
+type UnimplementedDASServer struct{}
+
+func RegisterDASServer(s grpc.ServiceRegistrar, srv DASServer) {
+	s.RegisterService(&_DAS_serviceDesc, srv)
+}
+
+func _DAS_Store_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DASServer).Store(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dasrpc.DAS/Store",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DASServer).Store(ctx, req.(*StoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DAS_GetByHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DASServer).GetByHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dasrpc.DAS/GetByHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DASServer).GetByHash(ctx, req.(*GetByHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DAS_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dasrpc.DAS",
+	HandlerType: (*DASServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Store",
+			Handler:    _DAS_Store_Handler,
+		},
+		{
+			MethodName: "GetByHash",
+			Handler:    _DAS_GetByHash_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "das.proto",
+}
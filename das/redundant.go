@@ -0,0 +1,275 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package das
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/offchainlabs/nitro/arbstate"
+)
+
+// RedundantBackendConfig describes one backend composed into a redundant
+// DAS. Type selects which DataAvailabilityService implementation to
+// construct ("local-disk", "remote", or "s3"); the matching Config field
+// holds that backend's own settings.
+type RedundantBackendConfig struct {
+	Type               string             `koanf:"type"`
+	LocalDiskDASConfig LocalDiskDASConfig `koanf:"local-disk"`
+	RemoteDASConfig    RemoteDASConfig    `koanf:"remote"`
+	S3Config           S3DASConfig        `koanf:"s3"`
+	RetryConfig        RetryConfig        `koanf:"retry"`
+}
+
+type RedundantConfig struct {
+	Backends    []RedundantBackendConfig `koanf:"backends"`
+	WritePolicy string                   `koanf:"write-policy"` // "all", "quorum:k", or "first-success"
+	ReadPolicy  string                   `koanf:"read-policy"`  // "first-success", "parallel-race", or "prefer-order"
+}
+
+var DefaultRedundantConfig = RedundantConfig{
+	WritePolicy: "all",
+	ReadPolicy:  "prefer-order",
+}
+
+func RedundantConfigAddOptions(prefix string, f *flag.FlagSet) {
+	f.String(prefix+".write-policy", DefaultRedundantConfig.WritePolicy, "write policy across redundant backends ('all', 'quorum:k', or 'first-success')")
+	f.String(prefix+".read-policy", DefaultRedundantConfig.ReadPolicy, "read policy across redundant backends ('first-success', 'parallel-race', or 'prefer-order')")
+	// Backends themselves are only configurable via conf.file/conf.string/conf.patch,
+	// following the same pattern as AggregatorConfig.Backends: a flag can't express
+	// an arbitrary-length list of heterogeneous per-backend settings.
+}
+
+// buildRedundantBackend constructs the DataAvailabilityService for one
+// RedundantBackendConfig entry, wrapping it in a RetryWrapper when
+// config.RetryConfig.Enable is set so a single backend's transient
+// failures don't immediately count against the redundant write/read
+// policy.
+func buildRedundantBackend(config RedundantBackendConfig) (DataAvailabilityService, error) {
+	var backend DataAvailabilityService
+	var err error
+	switch config.Type {
+	case LocalDiskDataAvailabilityString:
+		backend, err = NewLocalDiskDataAvailabilityService(config.LocalDiskDASConfig)
+	case RemoteDataAvailabilityString:
+		backend, err = NewRemoteDataAvailabilityService(config.RemoteDASConfig)
+	case S3DataAvailabilityString:
+		backend, err = NewS3DataAvailabilityService(config.S3Config)
+	default:
+		return nil, fmt.Errorf("unknown redundant DAS backend type: %q", config.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if config.RetryConfig.Enable {
+		backend, err = NewRetryWrapper(backend, config.RetryConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return backend, nil
+}
+
+// MultiError collects the per-backend errors from a redundant operation
+// that didn't fully succeed, in the order they arrived. Each error is kept
+// as its backend returned it; the backend's name is not added to it.
+type MultiError struct {
+	Errors []error
+}
+
+func (e *MultiError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return "multiple backend errors: [" + strings.Join(msgs, "; ") + "]"
+}
+
+type writePolicy interface {
+	// requiredSuccesses returns how many of n backends must succeed for the
+	// write as a whole to succeed.
+	requiredSuccesses(n int) int
+}
+
+type writeAll struct{}
+
+func (writeAll) requiredSuccesses(n int) int { return n }
+
+type writeFirstSuccess struct{}
+
+func (writeFirstSuccess) requiredSuccesses(int) int { return 1 }
+
+type writeQuorum struct{ k int }
+
+func (w writeQuorum) requiredSuccesses(int) int { return w.k }
+
+func parseWritePolicy(s string) (writePolicy, error) {
+	if s == "all" || s == "" {
+		return writeAll{}, nil
+	}
+	if s == "first-success" {
+		return writeFirstSuccess{}, nil
+	}
+	if strings.HasPrefix(s, "quorum:") {
+		k, err := strconv.Atoi(strings.TrimPrefix(s, "quorum:"))
+		if err != nil || k < 1 {
+			return nil, fmt.Errorf("invalid quorum write policy %q", s)
+		}
+		return writeQuorum{k: k}, nil
+	}
+	return nil, fmt.Errorf("unknown write policy %q", s)
+}
+
+// RedundantDataAvailabilityService composes N heterogeneous
+// DataAvailabilityService backends behind a single configurable write
+// policy (how many backends must accept a Store before it is considered
+// successful) and read policy (which backend(s) answer a GetByHash).
+type RedundantDataAvailabilityService struct {
+	backends    []DataAvailabilityService
+	writePolicy writePolicy
+	readPolicy  string
+}
+
+func NewRedundantDataAvailabilityService(config RedundantConfig) (*RedundantDataAvailabilityService, error) {
+	if len(config.Backends) == 0 {
+		return nil, errors.New("redundant DAS requires at least one backend")
+	}
+
+	backends := make([]DataAvailabilityService, 0, len(config.Backends))
+	for _, backendConfig := range config.Backends {
+		backend, err := buildRedundantBackend(backendConfig)
+		if err != nil {
+			return nil, err
+		}
+		backends = append(backends, backend)
+	}
+
+	policy, err := parseWritePolicy(config.WritePolicy)
+	if err != nil {
+		return nil, err
+	}
+	if quorum, ok := policy.(writeQuorum); ok && quorum.k > len(config.Backends) {
+		return nil, fmt.Errorf("write policy %q requires %d successes but only %d backends are configured", config.WritePolicy, quorum.k, len(config.Backends))
+	}
+
+	readPolicy := config.ReadPolicy
+	if readPolicy == "" {
+		readPolicy = DefaultRedundantConfig.ReadPolicy
+	}
+	switch readPolicy {
+	case "first-success", "parallel-race", "prefer-order":
+	default:
+		return nil, fmt.Errorf("unknown read policy %q", readPolicy)
+	}
+
+	return &RedundantDataAvailabilityService{
+		backends:    backends,
+		writePolicy: policy,
+		readPolicy:  readPolicy,
+	}, nil
+}
+
+type storeResult struct {
+	cert *arbstate.DataAvailabilityCertificate
+	err  error
+}
+
+// Store writes message to every backend concurrently, returning as soon
+// as the configured write policy's success threshold is met. Backends
+// still in flight at that point are canceled via ctx rather than awaited.
+func (r *RedundantDataAvailabilityService) Store(ctx context.Context, message []byte, timeout uint64, sig []byte) (*arbstate.DataAvailabilityCertificate, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan storeResult, len(r.backends))
+	for _, backend := range r.backends {
+		backend := backend
+		go func() {
+			cert, err := backend.Store(ctx, message, timeout, sig)
+			results <- storeResult{cert: cert, err: err}
+		}()
+	}
+
+	required := r.writePolicy.requiredSuccesses(len(r.backends))
+	var successes int
+	var firstCert *arbstate.DataAvailabilityCertificate
+	var errs []error
+
+	for i := 0; i < len(r.backends); i++ {
+		result := <-results
+		if result.err != nil {
+			errs = append(errs, result.err)
+			continue
+		}
+		successes++
+		if firstCert == nil {
+			firstCert = result.cert
+		}
+		if successes >= required {
+			return firstCert, nil
+		}
+	}
+
+	return nil, &MultiError{Errors: errs}
+}
+
+// GetByHash answers from the backends according to the configured read
+// policy: "prefer-order" tries each backend in configuration order until
+// one succeeds; "first-success" and "parallel-race" query all backends
+// concurrently and return the first successful response, returning a
+// MultiError only if every backend fails.
+func (r *RedundantDataAvailabilityService) GetByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	if r.readPolicy == "prefer-order" {
+		var errs []error
+		for _, backend := range r.backends {
+			data, err := backend.GetByHash(ctx, hash)
+			if err == nil {
+				return data, nil
+			}
+			errs = append(errs, err)
+		}
+		return nil, &MultiError{Errors: errs}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type getResult struct {
+		data []byte
+		err  error
+	}
+	results := make(chan getResult, len(r.backends))
+	for _, backend := range r.backends {
+		backend := backend
+		go func() {
+			data, err := backend.GetByHash(ctx, hash)
+			results <- getResult{data: data, err: err}
+		}()
+	}
+
+	var errs []error
+	for i := 0; i < len(r.backends); i++ {
+		result := <-results
+		if result.err == nil {
+			return result.data, nil
+		}
+		errs = append(errs, result.err)
+	}
+	return nil, &MultiError{Errors: errs}
+}
+
+func (r *RedundantDataAvailabilityService) String() string {
+	names := make([]string, len(r.backends))
+	for i, backend := range r.backends {
+		names[i] = backend.String()
+	}
+	return "RedundantDataAvailabilityService(" + strings.Join(names, ", ") + ")"
+}
@@ -0,0 +1,166 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package das
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/offchainlabs/nitro/arbstate"
+)
+
+// fakeRedundantBackend is a DataAvailabilityService used to drive
+// RedundantDataAvailabilityService.Store's goroutine/quorum/cancellation
+// logic directly. If delay is non-zero, Store blocks until delay elapses
+// or ctx is canceled; if canceled is non-nil, it's closed when that
+// happens, so a test can observe that a straggler was actually canceled
+// rather than left running.
+type fakeRedundantBackend struct {
+	name     string
+	delay    time.Duration
+	err      error
+	canceled chan struct{}
+}
+
+func (f *fakeRedundantBackend) Store(ctx context.Context, message []byte, timeout uint64, sig []byte) (*arbstate.DataAvailabilityCertificate, error) {
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			if f.canceled != nil {
+				close(f.canceled)
+			}
+			return nil, ctx.Err()
+		}
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &arbstate.DataAvailabilityCertificate{}, nil
+}
+
+func (f *fakeRedundantBackend) GetByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return nil, errors.New("fakeRedundantBackend.GetByHash not implemented")
+}
+
+func (f *fakeRedundantBackend) String() string { return f.name }
+
+func TestParseWritePolicy(t *testing.T) {
+	tests := []struct {
+		name             string
+		policy           string
+		wantErr          bool
+		requiredForThree int
+	}{
+		{"empty defaults to all", "", false, 3},
+		{"all", "all", false, 3},
+		{"first-success", "first-success", false, 1},
+		{"quorum", "quorum:2", false, 2},
+		{"quorum zero is invalid", "quorum:0", true, 0},
+		{"quorum non-numeric is invalid", "quorum:many", true, 0},
+		{"unknown policy is invalid", "bogus", true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := parseWritePolicy(tt.policy)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseWritePolicy(%q): expected an error, got nil", tt.policy)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseWritePolicy(%q) returned error: %v", tt.policy, err)
+			}
+			if got := policy.requiredSuccesses(3); got != tt.requiredForThree {
+				t.Errorf("requiredSuccesses(3) = %d, want %d", got, tt.requiredForThree)
+			}
+		})
+	}
+}
+
+func TestBuildRedundantBackendUnknownType(t *testing.T) {
+	if _, err := buildRedundantBackend(RedundantBackendConfig{Type: "bogus"}); err == nil {
+		t.Fatal("buildRedundantBackend with an unknown type: expected an error, got nil")
+	}
+}
+
+func TestNewRedundantDataAvailabilityServiceRejectsQuorumExceedingBackends(t *testing.T) {
+	config := RedundantConfig{
+		Backends: []RedundantBackendConfig{
+			{Type: RemoteDataAvailabilityString, RemoteDASConfig: RemoteDASConfig{URLs: []string{"http://backend-a"}}},
+			{Type: RemoteDataAvailabilityString, RemoteDASConfig: RemoteDASConfig{URLs: []string{"http://backend-b"}}},
+		},
+		WritePolicy: "quorum:3",
+	}
+	if _, err := NewRedundantDataAvailabilityService(config); err == nil {
+		t.Fatal("NewRedundantDataAvailabilityService with quorum > backend count: expected an error, got nil")
+	}
+}
+
+func TestRedundantStoreQuorumCancelsStragglers(t *testing.T) {
+	canceled := make(chan struct{})
+	svc := &RedundantDataAvailabilityService{
+		backends: []DataAvailabilityService{
+			&fakeRedundantBackend{name: "a"},
+			&fakeRedundantBackend{name: "b"},
+			&fakeRedundantBackend{name: "straggler", delay: time.Hour, canceled: canceled},
+		},
+		writePolicy: writeQuorum{k: 2},
+		readPolicy:  DefaultRedundantConfig.ReadPolicy,
+	}
+
+	cert, err := svc.Store(context.Background(), []byte("message"), 0, nil)
+	if err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	if cert == nil {
+		t.Fatal("Store returned a nil certificate")
+	}
+
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("straggler backend was never canceled after quorum was reached")
+	}
+}
+
+func TestRedundantStoreFirstSuccessPolicyToleratesOtherFailures(t *testing.T) {
+	svc := &RedundantDataAvailabilityService{
+		backends: []DataAvailabilityService{
+			&fakeRedundantBackend{name: "failing", err: errors.New("boom")},
+			&fakeRedundantBackend{name: "succeeding"},
+		},
+		writePolicy: writeFirstSuccess{},
+		readPolicy:  DefaultRedundantConfig.ReadPolicy,
+	}
+
+	if _, err := svc.Store(context.Background(), []byte("message"), 0, nil); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+}
+
+func TestRedundantStoreQuorumNotMetReturnsMultiError(t *testing.T) {
+	svc := &RedundantDataAvailabilityService{
+		backends: []DataAvailabilityService{
+			&fakeRedundantBackend{name: "a", err: errors.New("a failed")},
+			&fakeRedundantBackend{name: "b", err: errors.New("b failed")},
+		},
+		writePolicy: writeAll{},
+		readPolicy:  DefaultRedundantConfig.ReadPolicy,
+	}
+
+	_, err := svc.Store(context.Background(), []byte("message"), 0, nil)
+	var multiErr *MultiError
+	if !errors.As(err, &multiErr) {
+		t.Fatalf("Store error = %v, want a *MultiError", err)
+	}
+	if len(multiErr.Errors) != 2 {
+		t.Errorf("len(multiErr.Errors) = %d, want 2", len(multiErr.Errors))
+	}
+}
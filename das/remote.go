@@ -0,0 +1,109 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package das
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/offchainlabs/nitro/arbstate"
+)
+
+// RemoteTransport is the interface a wire protocol must implement in order
+// to back a RemoteDataAvailabilityService. HTTP+JSON and gRPC
+// implementations are provided; operators pick one via
+// RemoteDASConfig.Transport.
+type RemoteTransport interface {
+	Store(ctx context.Context, message []byte, timeout uint64, sig []byte) ([]byte, error)
+	GetByHash(ctx context.Context, hash common.Hash) ([]byte, error)
+	fmt.Stringer
+}
+
+type RemoteDASConfig struct {
+	Transport  string        `koanf:"transport"`    // "http" or "grpc"
+	URLs       []string      `koanf:"urls"`         // one or more endpoints of the remote DAS
+	Timeout    time.Duration `koanf:"timeout"`      // per-request timeout
+	TLSCert    string        `koanf:"tls-cert"`     // path to a client TLS certificate, or empty for plaintext/system trust
+	TLSKey     string        `koanf:"tls-key"`      // path to the client TLS private key matching TLSCert
+	CACert     string        `koanf:"ca-cert"`      // path to a CA bundle to trust, or empty to use the system pool
+	BearerAuth string        `koanf:"bearer-token"` // optional bearer token sent with every request
+}
+
+var DefaultRemoteDASConfig = RemoteDASConfig{
+	Transport: "http",
+	Timeout:   time.Minute,
+}
+
+func RemoteDASConfigAddOptions(prefix string, f *flag.FlagSet) {
+	f.String(prefix+".transport", DefaultRemoteDASConfig.Transport, "transport to use to reach the remote DAS ('http' or 'grpc')")
+	f.StringSlice(prefix+".urls", DefaultRemoteDASConfig.URLs, "URL(s) of the remote DAS")
+	f.Duration(prefix+".timeout", DefaultRemoteDASConfig.Timeout, "timeout for requests to the remote DAS")
+	f.String(prefix+".tls-cert", DefaultRemoteDASConfig.TLSCert, "path to a client TLS certificate, if required by the remote DAS")
+	f.String(prefix+".tls-key", DefaultRemoteDASConfig.TLSKey, "path to the client TLS private key matching tls-cert")
+	f.String(prefix+".ca-cert", DefaultRemoteDASConfig.CACert, "path to a CA bundle to trust for the remote DAS, or empty to use the system pool")
+	f.String(prefix+".bearer-token", DefaultRemoteDASConfig.BearerAuth, "bearer token to send with every request to the remote DAS, or empty if none")
+}
+
+// RemoteDataAvailabilityService implements DataAvailabilityService by
+// delegating Store and GetByHash calls to an out-of-process DAS over the
+// network, via a pluggable RemoteTransport (HTTP+JSON or gRPC).
+type RemoteDataAvailabilityService struct {
+	transport RemoteTransport
+}
+
+func NewRemoteDataAvailabilityService(config RemoteDASConfig) (*RemoteDataAvailabilityService, error) {
+	if len(config.URLs) == 0 {
+		return nil, errors.New("remote DAS requires at least one URL")
+	}
+
+	var transport RemoteTransport
+	var err error
+	switch config.Transport {
+	case "", "http":
+		transport, err = NewHTTPRemoteTransport(config)
+	case "grpc":
+		transport, err = NewGRPCRemoteTransport(config)
+	default:
+		return nil, errors.New("unknown remote DAS transport: " + config.Transport)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &RemoteDataAvailabilityService{transport: transport}, nil
+}
+
+func (r *RemoteDataAvailabilityService) Store(ctx context.Context, message []byte, timeout uint64, sig []byte) (*arbstate.DataAvailabilityCertificate, error) {
+	raw, err := r.transport.Store(ctx, message, timeout, sig)
+	if err != nil {
+		return nil, err
+	}
+	return Deserialize(raw)
+}
+
+// GetByHash verifies the data returned by the transport hashes to the
+// requested hash before returning it, exactly as S3DataAvailabilityService
+// does, since the transport talks to an out-of-process, potentially
+// untrusted remote DAS that could otherwise return arbitrary bytes for any
+// hash.
+func (r *RemoteDataAvailabilityService) GetByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	data, err := r.transport.GetByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if gotHash := DASDataHash(data); gotHash != hash {
+		return nil, fmt.Errorf("remote DAS %s returned data with hash mismatch: expected %v, got %v", r.transport, hash, gotHash)
+	}
+	return data, nil
+}
+
+func (r *RemoteDataAvailabilityService) String() string {
+	return "RemoteDataAvailabilityService(" + r.transport.String() + ")"
+}
@@ -0,0 +1,129 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package das
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/offchainlabs/nitro/arbstate"
+	"github.com/offchainlabs/nitro/das/dasrpc"
+)
+
+// GRPCRemoteTransport implements RemoteTransport over gRPC, for operators
+// who'd rather run the remote DAS behind a gRPC endpoint than an HTTP+JSON
+// one. Only the first configured URL is dialed; operators wanting
+// redundancy across multiple remote DASes should use the redundant DAS
+// mode instead.
+type GRPCRemoteTransport struct {
+	config RemoteDASConfig
+	conn   *grpc.ClientConn
+	client dasrpc.DASClient
+}
+
+func NewGRPCRemoteTransport(config RemoteDASConfig) (*GRPCRemoteTransport, error) {
+	if len(config.URLs) == 0 {
+		return nil, fmt.Errorf("gRPC remote DAS transport requires at least one URL")
+	}
+
+	var creds credentials.TransportCredentials
+	if config.TLSCert != "" || config.CACert != "" {
+		tlsConfig, err := remoteTLSConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	} else {
+		creds = insecure.NewCredentials()
+	}
+
+	conn, err := grpc.Dial(config.URLs[0], grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("error dialing remote DAS at %s: %w", config.URLs[0], err)
+	}
+
+	return &GRPCRemoteTransport{
+		config: config,
+		conn:   conn,
+		client: dasrpc.NewDASClient(conn),
+	}, nil
+}
+
+func (t *GRPCRemoteTransport) authContext(ctx context.Context) context.Context {
+	if t.config.BearerAuth == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+t.config.BearerAuth)
+}
+
+// withTimeout derives a context bounded by config.Timeout, mirroring the
+// deadline HTTPRemoteTransport gets for free from http.Client.Timeout.
+func (t *GRPCRemoteTransport) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if t.config.Timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, t.config.Timeout)
+}
+
+func (t *GRPCRemoteTransport) Store(ctx context.Context, message []byte, timeout uint64, sig []byte) ([]byte, error) {
+	ctx, cancel := t.withTimeout(ctx)
+	defer cancel()
+	resp, err := t.client.Store(t.authContext(ctx), &dasrpc.StoreRequest{
+		Message: message,
+		Timeout: timeout,
+		Sig:     sig,
+	})
+	if err != nil {
+		return nil, grpcStatusError(err)
+	}
+	return resp.DataAvailabilityCertificate, nil
+}
+
+func (t *GRPCRemoteTransport) GetByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	ctx, cancel := t.withTimeout(ctx)
+	defer cancel()
+	resp, err := t.client.GetByHash(t.authContext(ctx), &dasrpc.GetByHashRequest{
+		DataHash: hash[:],
+	})
+	if err != nil {
+		return nil, grpcStatusError(err)
+	}
+	return resp.Data, nil
+}
+
+func (t *GRPCRemoteTransport) String() string {
+	return fmt.Sprintf("GRPCRemoteTransport(%v)", t.config.URLs)
+}
+
+// grpcStatusError translates a gRPC status error into the error vocabulary
+// httpStatusError uses for the HTTP transport: connection-level and
+// server-overload codes are wrapped in ErrBackendUnavailable, codes.NotFound
+// becomes arbstate.ErrNotFound, and anything else is returned unchanged,
+// which IsRetryableError does not retry. That includes the codes.Unknown
+// grpc-go assigns to a plain error returned by the DAS server's Store/
+// GetByHash; the HTTP transport receives the same server error as a 5xx
+// status and retries it.
+func grpcStatusError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	switch st.Code() {
+	case codes.NotFound:
+		return arbstate.ErrNotFound
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return fmt.Errorf("%w: %s", ErrBackendUnavailable, st.Message())
+	default:
+		return err
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package das
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/offchainlabs/nitro/arbstate"
+)
+
+// httpStoreRequest / httpStoreResponse / httpGetByHashResponse are the
+// JSON wire types for the HTTP+JSON RemoteTransport. Binary fields are
+// base64-encoded by the standard encoding/json []byte handling.
+type httpStoreRequest struct {
+	Message []byte `json:"message"`
+	Timeout uint64 `json:"timeout"`
+	Sig     []byte `json:"sig"`
+}
+
+type httpStoreResponse struct {
+	DataAvailabilityCertificate []byte `json:"dataAvailabilityCertificate"`
+}
+
+type httpGetByHashResponse struct {
+	Data []byte `json:"data"`
+}
+
+// HTTPRemoteTransport implements RemoteTransport by issuing JSON requests
+// against a remote DAS's HTTP API (POST /store, GET /get-by-hash/<hash>).
+type HTTPRemoteTransport struct {
+	config RemoteDASConfig
+	client *http.Client
+}
+
+func NewHTTPRemoteTransport(config RemoteDASConfig) (*HTTPRemoteTransport, error) {
+	tlsConfig, err := remoteTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HTTPRemoteTransport{
+		config: config,
+		client: &http.Client{
+			Timeout: config.Timeout,
+			Transport: &http.Transport{
+				TLSClientConfig: tlsConfig,
+			},
+		},
+	}, nil
+}
+
+func remoteTLSConfig(config RemoteDASConfig) (*tls.Config, error) {
+	if config.TLSCert == "" && config.TLSKey == "" && config.CACert == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{}
+
+	if config.TLSCert != "" || config.TLSKey != "" {
+		cert, err := tls.LoadX509KeyPair(config.TLSCert, config.TLSKey)
+		if err != nil {
+			return nil, fmt.Errorf("error loading remote DAS client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if config.CACert != "" {
+		caBytes, err := os.ReadFile(config.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("error loading remote DAS CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBytes) {
+			return nil, errors.New("error parsing remote DAS CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+func (t *HTTPRemoteTransport) addAuth(req *http.Request) {
+	if t.config.BearerAuth != "" {
+		req.Header.Set("Authorization", "Bearer "+t.config.BearerAuth)
+	}
+}
+
+func (t *HTTPRemoteTransport) Store(ctx context.Context, message []byte, timeout uint64, sig []byte) ([]byte, error) {
+	body, err := json.Marshal(httpStoreRequest{Message: message, Timeout: timeout, Sig: sig})
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for _, url := range t.config.URLs {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url+"/store", bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		t.addAuth(req)
+
+		resp, err := t.client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		respBody, err := readAndClose(resp)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			lastErr = httpStatusError(url, resp.StatusCode, respBody)
+			continue
+		}
+
+		var storeResp httpStoreResponse
+		if err := json.Unmarshal(respBody, &storeResp); err != nil {
+			lastErr = err
+			continue
+		}
+		return storeResp.DataAvailabilityCertificate, nil
+	}
+
+	return nil, fmt.Errorf("all remote DAS URLs failed, last error: %w", lastErr)
+}
+
+func (t *HTTPRemoteTransport) GetByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	encodedHash := base64.URLEncoding.EncodeToString(hash[:])
+
+	var lastErr error
+	for _, url := range t.config.URLs {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/get-by-hash/"+encodedHash, nil)
+		if err != nil {
+			return nil, err
+		}
+		t.addAuth(req)
+
+		resp, err := t.client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		respBody, err := readAndClose(resp)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			lastErr = httpStatusError(url, resp.StatusCode, respBody)
+			continue
+		}
+
+		var getResp httpGetByHashResponse
+		if err := json.Unmarshal(respBody, &getResp); err != nil {
+			lastErr = err
+			continue
+		}
+		return getResp.Data, nil
+	}
+
+	return nil, fmt.Errorf("all remote DAS URLs failed, last error: %w", lastErr)
+}
+
+func (t *HTTPRemoteTransport) String() string {
+	return fmt.Sprintf("HTTPRemoteTransport(%v)", t.config.URLs)
+}
+
+func readAndClose(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// httpStatusError builds the error returned for a non-200 response. 404 is
+// reported as arbstate.ErrNotFound, matching S3DataAvailabilityService so
+// callers can use errors.Is(err, arbstate.ErrNotFound) regardless of which
+// backend answered. 5xx responses are wrapped in ErrBackendUnavailable so
+// IsRetryableError recognizes them as transient; every other status code
+// is treated as terminal, since a retry would just get the same response
+// again.
+func httpStatusError(url string, statusCode int, body []byte) error {
+	if statusCode == http.StatusNotFound {
+		return arbstate.ErrNotFound
+	}
+	err := fmt.Errorf("remote DAS %s returned status %d: %s", url, statusCode, body)
+	if statusCode >= 500 {
+		return fmt.Errorf("%w: %v", ErrBackendUnavailable, err)
+	}
+	return err
+}
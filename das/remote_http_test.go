@@ -0,0 +1,108 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package das
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestHTTPRemoteTransportStoreFallsBackToNextURL(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down for maintenance", http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"dataAvailabilityCertificate":"aGVsbG8="}`))
+	}))
+	defer up.Close()
+
+	transport, err := NewHTTPRemoteTransport(RemoteDASConfig{URLs: []string{down.URL, up.URL}})
+	if err != nil {
+		t.Fatalf("NewHTTPRemoteTransport: %v", err)
+	}
+
+	cert, err := transport.Store(context.Background(), []byte("message"), 0, nil)
+	if err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	if string(cert) != "hello" {
+		t.Errorf("Store returned %q, want %q", cert, "hello")
+	}
+}
+
+func TestHTTPRemoteTransportStoreAllURLsFail(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad request", http.StatusBadRequest)
+	}))
+	defer down.Close()
+
+	transport, err := NewHTTPRemoteTransport(RemoteDASConfig{URLs: []string{down.URL}})
+	if err != nil {
+		t.Fatalf("NewHTTPRemoteTransport: %v", err)
+	}
+
+	if _, err := transport.Store(context.Background(), []byte("message"), 0, nil); err == nil {
+		t.Fatal("Store with every URL failing: expected an error, got nil")
+	}
+}
+
+func TestHTTPRemoteTransportGetByHash(t *testing.T) {
+	hash := common.BytesToHash([]byte("hello"))
+	wantPath := "/get-by-hash/" + base64.URLEncoding.EncodeToString(hash[:])
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != wantPath {
+			t.Errorf("unexpected request path: got %s, want %s", r.URL.Path, wantPath)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":"d29ybGQ="}`))
+	}))
+	defer server.Close()
+
+	transport, err := NewHTTPRemoteTransport(RemoteDASConfig{URLs: []string{server.URL}})
+	if err != nil {
+		t.Fatalf("NewHTTPRemoteTransport: %v", err)
+	}
+
+	data, err := transport.GetByHash(context.Background(), hash)
+	if err != nil {
+		t.Fatalf("GetByHash: %v", err)
+	}
+	if string(data) != "world" {
+		t.Errorf("GetByHash returned %q, want %q", data, "world")
+	}
+}
+
+func TestHTTPStatusErrorClassification(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCode    int
+		wantRetryable bool
+	}{
+		{"internal server error", http.StatusInternalServerError, true},
+		{"service unavailable", http.StatusServiceUnavailable, true},
+		{"bad request", http.StatusBadRequest, false},
+		{"not found", http.StatusNotFound, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := httpStatusError("http://backend", tt.statusCode, []byte("boom"))
+			if got := errors.Is(err, ErrBackendUnavailable); got != tt.wantRetryable {
+				t.Errorf("httpStatusError(%d) retryable = %v, want %v", tt.statusCode, got, tt.wantRetryable)
+			}
+			if got := IsRetryableError(err); got != tt.wantRetryable {
+				t.Errorf("IsRetryableError(httpStatusError(%d)) = %v, want %v", tt.statusCode, got, tt.wantRetryable)
+			}
+		})
+	}
+}
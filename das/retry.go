@@ -0,0 +1,198 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package das
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/metrics"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/offchainlabs/nitro/arbstate"
+)
+
+type RetryConfig struct {
+	Enable         bool          `koanf:"enable"`
+	MaxAttempts    int           `koanf:"max-attempts"`
+	InitialBackoff time.Duration `koanf:"initial-backoff"`
+	MaxBackoff     time.Duration `koanf:"max-backoff"`
+	Jitter         float64       `koanf:"jitter"` // fraction of the backoff to randomize, in [0, 1]
+}
+
+var DefaultRetryConfig = RetryConfig{
+	Enable:         false,
+	MaxAttempts:    5,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	Jitter:         0.2,
+}
+
+func RetryConfigAddOptions(prefix string, f *flag.FlagSet) {
+	f.Bool(prefix+".enable", DefaultRetryConfig.Enable, "retry Store/GetByHash calls that fail with a transient error")
+	f.Int(prefix+".max-attempts", DefaultRetryConfig.MaxAttempts, "maximum number of attempts, including the first, before giving up")
+	f.Duration(prefix+".initial-backoff", DefaultRetryConfig.InitialBackoff, "backoff before the first retry")
+	f.Duration(prefix+".max-backoff", DefaultRetryConfig.MaxBackoff, "maximum backoff between retries")
+	f.Float64(prefix+".jitter", DefaultRetryConfig.Jitter, "fraction of the backoff to randomize, between 0 and 1")
+}
+
+// IsTerminalError reports whether an error returned by a
+// DataAvailabilityService should never be retried, because a retry cannot
+// possibly succeed: the request itself was rejected, as opposed to a
+// transient failure of the network or backend.
+func IsTerminalError(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.Is(err, ErrSignatureRejected) ||
+		errors.Is(err, ErrBadRequest) ||
+		errors.Is(err, ErrSignerMismatch)
+}
+
+// IsRetryableError reports whether an error returned by a
+// DataAvailabilityService is plausibly transient, and worth retrying:
+// network errors, 5xx responses from a remote backend, and
+// context.DeadlineExceeded. It cannot tell a sub-request's deadline from
+// the parent context's; RetryWrapper.do stops retrying once its own ctx is
+// done.
+func IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if IsTerminalError(err) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	if errors.Is(err, ErrBackendUnavailable) {
+		return true
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	return false
+}
+
+var (
+	ErrSignatureRejected  = errors.New("store signature rejected")
+	ErrBadRequest         = errors.New("bad request")
+	ErrSignerMismatch     = errors.New("store signer mismatch")
+	ErrBackendUnavailable = errors.New("backend unavailable")
+)
+
+// RetryWrapper wraps a DataAvailabilityService with retry-on-transient-error
+// and capped exponential backoff with jitter, honoring the parent
+// context.Context's deadline across all attempts. The redundant DAS mode
+// wraps each of its backends in a RetryWrapper when that backend's
+// RetryConfig.Enable is set, since partial quorum failures there are
+// usually transient.
+type RetryWrapper struct {
+	inner   DataAvailabilityService
+	config  RetryConfig
+	metrics retryMetrics
+}
+
+type retryMetrics struct {
+	attempts metrics.Counter
+	failures metrics.Counter
+}
+
+func newRetryMetrics(name string) retryMetrics {
+	return retryMetrics{
+		attempts: metrics.NewRegisteredCounter("das/retry/"+name+"/attempts", nil),
+		failures: metrics.NewRegisteredCounter("das/retry/"+name+"/failures", nil),
+	}
+}
+
+func NewRetryWrapper(inner DataAvailabilityService, config RetryConfig) (*RetryWrapper, error) {
+	if config.MaxAttempts < 1 {
+		return nil, fmt.Errorf("retry max-attempts must be at least 1, got %d", config.MaxAttempts)
+	}
+	return &RetryWrapper{
+		inner:   inner,
+		config:  config,
+		metrics: newRetryMetrics(inner.String()),
+	}, nil
+}
+
+func (r *RetryWrapper) backoff(attempt int) time.Duration {
+	backoff := r.config.InitialBackoff * time.Duration(1<<uint(attempt))
+	if backoff > r.config.MaxBackoff || backoff <= 0 {
+		backoff = r.config.MaxBackoff
+	}
+	if r.config.Jitter > 0 {
+		delta := float64(backoff) * r.config.Jitter
+		backoff = backoff - time.Duration(delta) + time.Duration(rand.Float64()*2*delta)
+	}
+	return backoff
+}
+
+// do runs fn, retrying on retryable errors up to config.MaxAttempts times
+// with capped exponential backoff, honoring ctx's deadline across all
+// attempts.
+func (r *RetryWrapper) do(ctx context.Context, fn func(ctx context.Context) error) error {
+	var lastErr error
+	for attempt := 0; attempt < r.config.MaxAttempts; attempt++ {
+		r.metrics.attempts.Inc(1)
+
+		lastErr = fn(ctx)
+		if lastErr == nil {
+			return nil
+		}
+
+		r.metrics.failures.Inc(1)
+		if !IsRetryableError(lastErr) {
+			return lastErr
+		}
+		if attempt == r.config.MaxAttempts-1 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(r.backoff(attempt)):
+		}
+	}
+
+	return lastErr
+}
+
+func (r *RetryWrapper) Store(ctx context.Context, message []byte, timeout uint64, sig []byte) (*arbstate.DataAvailabilityCertificate, error) {
+	var cert *arbstate.DataAvailabilityCertificate
+	err := r.do(ctx, func(ctx context.Context) error {
+		var err error
+		cert, err = r.inner.Store(ctx, message, timeout, sig)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cert, nil
+}
+
+func (r *RetryWrapper) GetByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	var data []byte
+	err := r.do(ctx, func(ctx context.Context) error {
+		var err error
+		data, err = r.inner.GetByHash(ctx, hash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (r *RetryWrapper) String() string {
+	return "RetryWrapper(" + r.inner.String() + ")"
+}
@@ -0,0 +1,184 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package das
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/offchainlabs/nitro/arbstate"
+)
+
+// fakeDAS is a DataAvailabilityService whose Store/GetByHash errors are
+// scripted by a caller-provided list, consumed one per call; once the list
+// is exhausted, calls succeed.
+type fakeDAS struct {
+	name       string
+	storeErrs  []error
+	getErrs    []error
+	storeCalls int
+	getCalls   int
+}
+
+func (f *fakeDAS) Store(ctx context.Context, message []byte, timeout uint64, sig []byte) (*arbstate.DataAvailabilityCertificate, error) {
+	if f.storeCalls < len(f.storeErrs) {
+		err := f.storeErrs[f.storeCalls]
+		f.storeCalls++
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		f.storeCalls++
+	}
+	return &arbstate.DataAvailabilityCertificate{}, nil
+}
+
+func (f *fakeDAS) GetByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	if f.getCalls < len(f.getErrs) {
+		err := f.getErrs[f.getCalls]
+		f.getCalls++
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		f.getCalls++
+	}
+	return []byte("data"), nil
+}
+
+func (f *fakeDAS) String() string { return f.name }
+
+type fakeNetError struct{}
+
+func (fakeNetError) Error() string   { return "fake net error" }
+func (fakeNetError) Timeout() bool   { return true }
+func (fakeNetError) Temporary() bool { return true }
+
+var _ net.Error = fakeNetError{}
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"net error", fakeNetError{}, true},
+		{"backend unavailable", ErrBackendUnavailable, true},
+		{"wrapped backend unavailable", fmt.Errorf("store failed: %w", ErrBackendUnavailable), true},
+		{"deadline exceeded", context.DeadlineExceeded, true},
+		{"signature rejected", ErrSignatureRejected, false},
+		{"bad request", ErrBadRequest, false},
+		{"signer mismatch", ErrSignerMismatch, false},
+		{"unrelated error", errors.New("boom"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryableError(tt.err); got != tt.want {
+				t.Errorf("IsRetryableError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsTerminalError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"signature rejected", ErrSignatureRejected, true},
+		{"bad request", ErrBadRequest, true},
+		{"signer mismatch", ErrSignerMismatch, true},
+		{"backend unavailable", ErrBackendUnavailable, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTerminalError(tt.err); got != tt.want {
+				t.Errorf("IsTerminalError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryWrapperBackoffBounds(t *testing.T) {
+	r := &RetryWrapper{
+		config: RetryConfig{
+			InitialBackoff: 100 * time.Millisecond,
+			MaxBackoff:     time.Second,
+			Jitter:         0.2,
+		},
+	}
+	for attempt := 0; attempt < 10; attempt++ {
+		backoff := r.backoff(attempt)
+		if backoff < 0 {
+			t.Fatalf("backoff(%d) = %v, want >= 0", attempt, backoff)
+		}
+		if backoff > r.config.MaxBackoff+time.Duration(float64(r.config.MaxBackoff)*r.config.Jitter) {
+			t.Fatalf("backoff(%d) = %v, exceeds max backoff plus jitter", attempt, backoff)
+		}
+	}
+}
+
+func TestNewRetryWrapperRejectsNonPositiveMaxAttempts(t *testing.T) {
+	for _, maxAttempts := range []int{0, -1} {
+		config := DefaultRetryConfig
+		config.MaxAttempts = maxAttempts
+		if _, err := NewRetryWrapper(nil, config); err == nil {
+			t.Errorf("NewRetryWrapper with MaxAttempts=%d: expected an error, got nil", maxAttempts)
+		}
+	}
+}
+
+func TestRetryWrapperStoreRetriesTransientThenSucceeds(t *testing.T) {
+	backend := &fakeDAS{
+		name:      "flaky",
+		storeErrs: []error{ErrBackendUnavailable, ErrBackendUnavailable},
+	}
+	wrapper, err := NewRetryWrapper(backend, RetryConfig{
+		MaxAttempts:    5,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewRetryWrapper: %v", err)
+	}
+
+	if _, err := wrapper.Store(context.Background(), []byte("message"), 0, nil); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	if backend.storeCalls != 3 {
+		t.Errorf("backend.storeCalls = %d, want 3 (2 failures then a success)", backend.storeCalls)
+	}
+}
+
+func TestRetryWrapperStoreGivesUpOnTerminalErrorWithoutExhaustingAttempts(t *testing.T) {
+	backend := &fakeDAS{
+		name:      "rejecting",
+		storeErrs: []error{ErrSignatureRejected},
+	}
+	wrapper, err := NewRetryWrapper(backend, RetryConfig{
+		MaxAttempts:    5,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewRetryWrapper: %v", err)
+	}
+
+	_, err = wrapper.Store(context.Background(), []byte("message"), 0, nil)
+	if !errors.Is(err, ErrSignatureRejected) {
+		t.Fatalf("Store error = %v, want ErrSignatureRejected", err)
+	}
+	if backend.storeCalls != 1 {
+		t.Errorf("backend.storeCalls = %d, want 1 (terminal error should not be retried)", backend.storeCalls)
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package das
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/ethereum/go-ethereum/common"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/offchainlabs/nitro/arbstate"
+	"github.com/offchainlabs/nitro/blsSignatures"
+)
+
+// timeoutMetadataKey is the S3 object metadata key used to record a
+// message's DAS timeout (UTC unix seconds), so an S3 lifecycle rule can
+// be configured to expire objects tagged past their timeout.
+const timeoutMetadataKey = "Das-Timeout"
+
+// blsKeyFileName is the name of the BLS private key file inside KeyDir,
+// matching the LocalDiskDASConfig convention.
+const blsKeyFileName = "bls.priv"
+
+type S3DASConfig struct {
+	AccessKey           string `koanf:"access-key"`
+	SecretKey           string `koanf:"secret-key"`
+	Region              string `koanf:"region"`
+	Bucket              string `koanf:"bucket"`
+	ObjectPrefix        string `koanf:"object-prefix"`
+	KmsKeyId            string `koanf:"kms-key-id"` // optional; if set, objects are encrypted with this KMS key
+	Endpoint            string `koanf:"endpoint"`   // optional override, for MinIO-compatible stores
+	DiscardAfterTimeout bool   `koanf:"discard-after-timeout"`
+	KeyDir              string `koanf:"key-dir"`  // directory holding the BLS keypair used to sign certificates
+	PrivKey             string `koanf:"priv-key"` // hex-encoded BLS private key, instead of KeyDir
+}
+
+var DefaultS3DASConfig = S3DASConfig{
+	DiscardAfterTimeout: true,
+}
+
+func S3DASConfigAddOptions(prefix string, f *flag.FlagSet) {
+	f.String(prefix+".access-key", DefaultS3DASConfig.AccessKey, "S3 access key")
+	f.String(prefix+".secret-key", DefaultS3DASConfig.SecretKey, "S3 secret key")
+	f.String(prefix+".region", DefaultS3DASConfig.Region, "S3 region")
+	f.String(prefix+".bucket", DefaultS3DASConfig.Bucket, "S3 bucket")
+	f.String(prefix+".object-prefix", DefaultS3DASConfig.ObjectPrefix, "prefix to prepend to the DataHash-derived S3 object key")
+	f.String(prefix+".kms-key-id", DefaultS3DASConfig.KmsKeyId, "KMS key id to encrypt stored objects with, or empty to request no server-side encryption (the bucket's default applies)")
+	f.String(prefix+".endpoint", DefaultS3DASConfig.Endpoint, "S3-compatible endpoint override, e.g. for a MinIO deployment")
+	f.Bool(prefix+".discard-after-timeout", DefaultS3DASConfig.DiscardAfterTimeout, "tag stored objects so a matching bucket lifecycle rule can expire them after their DAS timeout")
+	f.String(prefix+".key-dir", DefaultS3DASConfig.KeyDir, "directory holding the BLS keypair used to sign certificates")
+	f.String(prefix+".priv-key", DefaultS3DASConfig.PrivKey, "hex-encoded BLS private key used to sign certificates, instead of --data-availability.s3.key-dir")
+}
+
+// S3DataAvailabilityService implements DataAvailabilityService by storing
+// each message as an S3 object keyed by its DataHash, and signs the
+// returned certificate with its own BLS key exactly as
+// LocalDiskDataAvailabilityService does, so it can stand on its own as a
+// DAS committee member (directly as --data-availability.mode=s3, or
+// composed into the aggregator/redundant modes).
+type S3DataAvailabilityService struct {
+	config     S3DASConfig
+	client     *s3.S3
+	privKey    blsSignatures.PrivateKey
+	pubKey     blsSignatures.PublicKey
+	keysetHash common.Hash
+}
+
+func NewS3DataAvailabilityService(config S3DASConfig) (*S3DataAvailabilityService, error) {
+	if config.Bucket == "" {
+		return nil, errors.New("--data-availability.s3.bucket must be specified if mode is set to s3")
+	}
+	if config.KeyDir == "" && config.PrivKey == "" {
+		return nil, errors.New("--data-availability.s3.key-dir or .priv-key must be specified so stored certificates can be signed")
+	}
+
+	privKey, err := loadS3BLSPrivateKey(config)
+	if err != nil {
+		return nil, fmt.Errorf("error loading BLS private key: %w", err)
+	}
+	pubKey, err := blsSignatures.PublicKeyFromPrivateKey(privKey)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving BLS public key: %w", err)
+	}
+	keyset := arbstate.DataAvailabilityKeyset{
+		AssumedHonest: 1,
+		PubKeys:       []blsSignatures.PublicKey{pubKey},
+	}
+	keysetHash, err := keyset.Hash()
+	if err != nil {
+		return nil, fmt.Errorf("error hashing single-member keyset: %w", err)
+	}
+
+	awsConfig := aws.NewConfig()
+	if config.Region != "" {
+		awsConfig = awsConfig.WithRegion(config.Region)
+	}
+	if config.Endpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(config.Endpoint).WithS3ForcePathStyle(true)
+	}
+	if config.AccessKey != "" || config.SecretKey != "" {
+		awsConfig = awsConfig.WithCredentials(credentials.NewStaticCredentials(config.AccessKey, config.SecretKey, ""))
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating S3 session: %w", err)
+	}
+
+	return &S3DataAvailabilityService{
+		config:     config,
+		client:     s3.New(sess),
+		privKey:    privKey,
+		pubKey:     pubKey,
+		keysetHash: keysetHash,
+	}, nil
+}
+
+// loadS3BLSPrivateKey reads the BLS private key either directly from
+// PrivKey (hex-encoded) or from the bls.priv file in KeyDir, mirroring
+// LocalDiskDASConfig's KeyDir/PrivKey precedence.
+func loadS3BLSPrivateKey(config S3DASConfig) (blsSignatures.PrivateKey, error) {
+	if config.PrivKey != "" {
+		decoded, err := hex.DecodeString(config.PrivKey)
+		if err != nil {
+			return nil, fmt.Errorf("error hex-decoding priv-key: %w", err)
+		}
+		return blsSignatures.PrivateKeyFromBytes(decoded)
+	}
+
+	keyBytes, err := os.ReadFile(filepath.Join(config.KeyDir, blsKeyFileName))
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", blsKeyFileName, err)
+	}
+	decoded, err := hex.DecodeString(strings.TrimSpace(string(keyBytes)))
+	if err != nil {
+		return nil, fmt.Errorf("error hex-decoding %s: %w", blsKeyFileName, err)
+	}
+	return blsSignatures.PrivateKeyFromBytes(decoded)
+}
+
+func (s *S3DataAvailabilityService) objectKey(hash common.Hash) string {
+	key := hash.Hex()
+	if s.config.ObjectPrefix == "" {
+		return key
+	}
+	return strings.TrimSuffix(s.config.ObjectPrefix, "/") + "/" + key
+}
+
+func (s *S3DataAvailabilityService) Store(ctx context.Context, message []byte, timeout uint64, sig []byte) (*arbstate.DataAvailabilityCertificate, error) {
+	hash := DASDataHash(message)
+
+	putInput := &s3.PutObjectInput{
+		Bucket: aws.String(s.config.Bucket),
+		Key:    aws.String(s.objectKey(hash)),
+		Body:   bytes.NewReader(message),
+		Metadata: map[string]*string{
+			timeoutMetadataKey: aws.String(strconv.FormatUint(timeout, 10)),
+		},
+	}
+	if s.config.KmsKeyId != "" {
+		putInput.ServerSideEncryption = aws.String(s3.ServerSideEncryptionAwsKms)
+		putInput.SSEKMSKeyId = aws.String(s.config.KmsKeyId)
+	}
+	if s.config.DiscardAfterTimeout {
+		putInput.Tagging = aws.String("das-expire=true")
+	}
+
+	if _, err := s.client.PutObjectWithContext(ctx, putInput); err != nil {
+		return nil, fmt.Errorf("error storing object in S3: %w", err)
+	}
+
+	cert := &arbstate.DataAvailabilityCertificate{
+		DataHash:    hash,
+		Timeout:     timeout,
+		SignersMask: 1,
+		KeysetHash:  s.keysetHash,
+	}
+	signature, err := blsSignatures.SignMessage(s.privKey, serializeSignableFields(cert))
+	if err != nil {
+		return nil, fmt.Errorf("error signing data availability certificate: %w", err)
+	}
+	cert.Sig = signature
+
+	return cert, nil
+}
+
+func (s *S3DataAvailabilityService) GetByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	getOutput, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.config.Bucket),
+		Key:    aws.String(s.objectKey(hash)),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && (awsErr.Code() == s3.ErrCodeNoSuchKey || awsErr.Code() == "NotFound") {
+			return nil, arbstate.ErrNotFound
+		}
+		return nil, fmt.Errorf("error fetching object from S3: %w", err)
+	}
+	defer getOutput.Body.Close()
+
+	data, err := io.ReadAll(getOutput.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	gotHash := DASDataHash(data)
+	if gotHash != hash {
+		return nil, fmt.Errorf("S3 object %v hash mismatch: expected %v, got %v", s.objectKey(hash), hash, gotHash)
+	}
+
+	return data, nil
+}
+
+func (s *S3DataAvailabilityService) String() string {
+	return fmt.Sprintf("S3DataAvailabilityService(%s)", s.config.Bucket)
+}
@@ -0,0 +1,65 @@
+// Copyright 2021-2022, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package das
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+// TestS3DataAvailabilityServiceAgainstMinIO exercises Store/GetByHash
+// against a real S3-compatible endpoint (e.g. a local MinIO container).
+// It is skipped unless DAS_S3_TEST_ENDPOINT is set, since no such server
+// is available in a plain `go test` run.
+//
+// Example, with MinIO running locally:
+//
+//	docker run -p 9000:9000 -e MINIO_ROOT_USER=minioadmin -e MINIO_ROOT_PASSWORD=minioadmin minio/minio server /data
+//	mc mb local/das-test
+//	DAS_S3_TEST_ENDPOINT=http://127.0.0.1:9000 \
+//	DAS_S3_TEST_BUCKET=das-test \
+//	DAS_S3_TEST_ACCESS_KEY=minioadmin \
+//	DAS_S3_TEST_SECRET_KEY=minioadmin \
+//	go test ./das/... -run TestS3DataAvailabilityServiceAgainstMinIO
+func TestS3DataAvailabilityServiceAgainstMinIO(t *testing.T) {
+	endpoint := os.Getenv("DAS_S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("DAS_S3_TEST_ENDPOINT not set, skipping MinIO integration test")
+	}
+	bucket := os.Getenv("DAS_S3_TEST_BUCKET")
+	if bucket == "" {
+		t.Fatal("DAS_S3_TEST_BUCKET must be set alongside DAS_S3_TEST_ENDPOINT")
+	}
+
+	config := S3DASConfig{
+		Endpoint:  endpoint,
+		Bucket:    bucket,
+		AccessKey: os.Getenv("DAS_S3_TEST_ACCESS_KEY"),
+		SecretKey: os.Getenv("DAS_S3_TEST_SECRET_KEY"),
+		PrivKey:   os.Getenv("DAS_S3_TEST_BLS_PRIV_KEY"),
+	}
+	if config.PrivKey == "" {
+		t.Fatal("DAS_S3_TEST_BLS_PRIV_KEY must be set alongside DAS_S3_TEST_ENDPOINT")
+	}
+
+	service, err := NewS3DataAvailabilityService(config)
+	if err != nil {
+		t.Fatalf("NewS3DataAvailabilityService: %v", err)
+	}
+
+	message := []byte("minio integration test message")
+	cert, err := service.Store(context.Background(), message, 0, nil)
+	if err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	got, err := service.GetByHash(context.Background(), cert.DataHash)
+	if err != nil {
+		t.Fatalf("GetByHash: %v", err)
+	}
+	if string(got) != string(message) {
+		t.Errorf("GetByHash returned %q, want %q", got, message)
+	}
+}